@@ -0,0 +1,192 @@
+package funcs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// defaultDateLayouts maps named layout aliases, drawn from the time
+// package's format constants plus an "iso8601" alias, to the Go
+// time-layout strings dateFormat and dateParse understand. Lookups are
+// case-insensitive.
+var defaultDateLayouts = map[string]string{
+	"ansic":       time.ANSIC,
+	"unixdate":    time.UnixDate,
+	"rubydate":    time.RubyDate,
+	"rfc822":      time.RFC822,
+	"rfc822z":     time.RFC822Z,
+	"rfc850":      time.RFC850,
+	"rfc1123":     time.RFC1123,
+	"rfc1123z":    time.RFC1123Z,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"iso8601":     "2006-01-02T15:04:05Z07:00",
+	"kitchen":     time.Kitchen,
+	"stamp":       time.Stamp,
+	"stampmilli":  time.StampMilli,
+	"stampmicro":  time.StampMicro,
+	"stampnano":   time.StampNano,
+	"datetime":    time.DateTime,
+	"dateonly":    time.DateOnly,
+	"timeonly":    time.TimeOnly,
+}
+
+// resolveDateLayout resolves a named layout alias (e.g. "rfc3339") to its Go
+// time-layout string via defaultDateLayouts. Anything it doesn't recognize
+// is returned unchanged, on the assumption it's already a literal Go layout.
+func resolveDateLayout(layout string) string {
+	if l, ok := defaultDateLayouts[strings.ToLower(layout)]; ok {
+		return l
+	}
+	return layout
+}
+
+// cloneDateLayouts returns a copy of defaultDateLayouts an Executor can
+// layer custom aliases on top of without mutating the package-level map.
+func cloneDateLayouts() map[string]string {
+	m := make(map[string]string, len(defaultDateLayouts))
+	for k, v := range defaultDateLayouts {
+		m[k] = v
+	}
+	return m
+}
+
+// formatDate formats t per layout, special-casing the "unix" and
+// "unixmilli" pseudo-layouts as epoch integers and otherwise resolving
+// layout through resolve before calling t.Format.
+func formatDate(t time.Time, layout string, resolve func(string) string) string {
+	switch strings.ToLower(layout) {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unixmilli":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return t.Format(resolve(layout))
+}
+
+// parseDate parses value as a date per layout, the inverse of formatDate.
+// layout may be a named alias, a literal Go layout, one of the "unix" /
+// "unixmilli" epoch pseudo-layouts, or "auto", which tries RFC3339, then
+// RFC1123, then RFC822, then a numeric Unix epoch, in that order.
+func parseDate(layout string, value interface{}, resolve func(string) string) (time.Time, error) {
+	switch strings.ToLower(layout) {
+	case "auto":
+		return parseDateAuto(value)
+	case "unix":
+		n, err := cast.ToInt64E(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(n, 0), nil
+	case "unixmilli":
+		n, err := cast.ToInt64E(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(n), nil
+	}
+
+	s, err := cast.ToStringE(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(resolve(layout), s)
+}
+
+// parseDateAuto implements parseDate's "auto" heuristic mode.
+func parseDateAuto(value interface{}) (time.Time, error) {
+	s, strErr := cast.ToStringE(value)
+	if strErr == nil {
+		for _, layout := range []string{time.RFC3339, time.RFC1123, time.RFC822} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+
+	if n, err := cast.ToInt64E(value); err == nil {
+		return time.Unix(n, 0), nil
+	}
+
+	if strErr != nil {
+		return time.Time{}, strErr
+	}
+	return time.Time{}, fmt.Errorf("funcs: dateParse: unable to parse %q as a date", s)
+}
+
+// dateParse parses value as a date per layout. See parseDate for the
+// supported layout forms.
+func dateParse(layout string, value interface{}) (time.Time, error) {
+	return parseDate(layout, value, resolveDateLayout)
+}
+
+// dateAgoBuckets are the coarse granularities dateAgo renders its output
+// in, each paired with the duration below which it applies.
+var dateAgoBuckets = []struct {
+	under time.Duration
+	unit  time.Duration
+	name  string
+}{
+	{time.Minute, time.Second, "second"},
+	{time.Hour, time.Minute, "minute"},
+	{24 * time.Hour, time.Hour, "hour"},
+	{30 * 24 * time.Hour, 24 * time.Hour, "day"},
+	{12 * 30 * 24 * time.Hour, 30 * 24 * time.Hour, "month"},
+}
+
+// humanizeDateDiff renders d, the signed duration elapsed since t (now minus
+// t, so negative for a future t), as a coarse human string such as
+// "3 minutes ago" or "in 2 days".
+func humanizeDateDiff(d time.Duration) string {
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	unit := 365 * 24 * time.Hour
+	name := "year"
+	for _, b := range dateAgoBuckets {
+		if d < b.under {
+			unit, name = b.unit, b.name
+			break
+		}
+	}
+
+	amount := int(d / unit)
+	if amount != 1 {
+		name += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", amount, name)
+	}
+	return fmt.Sprintf("%d %s ago", amount, name)
+}
+
+// dateAgo returns a coarse, human-readable description of how long ago (or,
+// for a future time, how soon) t is relative to time.Now, e.g.
+// "3 minutes ago" or "in 2 days".
+func dateAgo(v interface{}) (string, error) {
+	t, err := cast.ToTimeE(v)
+	if err != nil {
+		return "", err
+	}
+	return humanizeDateDiff(time.Since(t)), nil
+}
+
+// dateIn returns t converted into the named IANA time zone, e.g.
+// `{{ dateIn "America/New_York" .T }}`.
+func dateIn(tz string, v interface{}) (time.Time, error) {
+	t, err := cast.ToTimeE(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
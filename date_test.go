@@ -0,0 +1,166 @@
+package funcs
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDateFormatAliases(t *testing.T) {
+	ref := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		layout string
+		want   string
+	}{
+		{"rfc3339", ref.Format(time.RFC3339)},
+		{"RFC3339", ref.Format(time.RFC3339)},
+		{"kitchen", ref.Format(time.Kitchen)},
+		{"iso8601", ref.Format("2006-01-02T15:04:05Z07:00")},
+		{"unix", strconv.FormatInt(ref.Unix(), 10)},
+		{"unixmilli", strconv.FormatInt(ref.UnixMilli(), 10)},
+		{"2006-01-02", "2024-03-05"},
+	}
+
+	for _, tc := range cases {
+		got, err := dateFormat(tc.layout, ref)
+		if err != nil {
+			t.Fatalf("dateFormat(%q) error = %v", tc.layout, err)
+		}
+		if got != tc.want {
+			t.Errorf("dateFormat(%q) = %q, want %q", tc.layout, got, tc.want)
+		}
+	}
+}
+
+func TestDateParse(t *testing.T) {
+	ref := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	t.Run("rfc3339", func(t *testing.T) {
+		got, err := dateParse("rfc3339", ref.Format(time.RFC3339))
+		if err != nil {
+			t.Fatalf("dateParse() error = %v", err)
+		}
+		if !got.Equal(ref) {
+			t.Errorf("dateParse() = %v, want %v", got, ref)
+		}
+	})
+
+	t.Run("unix", func(t *testing.T) {
+		got, err := dateParse("unix", ref.Unix())
+		if err != nil {
+			t.Fatalf("dateParse() error = %v", err)
+		}
+		if !got.Equal(ref) {
+			t.Errorf("dateParse() = %v, want %v", got, ref)
+		}
+	})
+
+	t.Run("auto RFC3339", func(t *testing.T) {
+		got, err := dateParse("auto", ref.Format(time.RFC3339))
+		if err != nil {
+			t.Fatalf("dateParse() error = %v", err)
+		}
+		if !got.Equal(ref) {
+			t.Errorf("dateParse() = %v, want %v", got, ref)
+		}
+	})
+
+	t.Run("auto RFC1123", func(t *testing.T) {
+		got, err := dateParse("auto", ref.Format(time.RFC1123))
+		if err != nil {
+			t.Fatalf("dateParse() error = %v", err)
+		}
+		if !got.Equal(ref) {
+			t.Errorf("dateParse() = %v, want %v", got, ref)
+		}
+	})
+
+	t.Run("auto numeric epoch", func(t *testing.T) {
+		got, err := dateParse("auto", ref.Unix())
+		if err != nil {
+			t.Fatalf("dateParse() error = %v", err)
+		}
+		if !got.Equal(ref) {
+			t.Errorf("dateParse() = %v, want %v", got, ref)
+		}
+	})
+
+	t.Run("auto unparseable", func(t *testing.T) {
+		if _, err := dateParse("auto", "not a date"); err == nil {
+			t.Error("dateParse() error = nil, want error")
+		}
+	})
+}
+
+func TestDateAgo(t *testing.T) {
+	cases := []struct {
+		delta time.Duration
+		want  string
+	}{
+		{-3 * time.Minute, "3 minutes ago"},
+		{-90 * time.Minute, "1 hour ago"},
+		// A few minutes past the 2-day mark, rather than exactly on it, so
+		// the elapsed time between building t and dateAgo computing
+		// time.Since(t) can't truncate the bucket down to 1 day.
+		{2*24*time.Hour + 5*time.Minute, "in 2 days"},
+	}
+
+	for _, tc := range cases {
+		got, err := dateAgo(time.Now().Add(tc.delta))
+		if err != nil {
+			t.Fatalf("dateAgo() error = %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("dateAgo(%v) = %q, want %q", tc.delta, got, tc.want)
+		}
+	}
+}
+
+func TestExecutorRegisterDateLayout(t *testing.T) {
+	ref := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	e := New()
+	e.RegisterDateLayout("short", "1/2/2006 15:04")
+
+	got, err := e.dateFormat("short", ref)
+	if err != nil {
+		t.Fatalf("dateFormat() error = %v", err)
+	}
+	if want := "3/5/2024 14:30"; got != want {
+		t.Errorf("dateFormat(%q, ref) = %q, want %q", "short", got, want)
+	}
+
+	parsed, err := e.dateParse("short", got)
+	if err != nil {
+		t.Fatalf("dateParse() error = %v", err)
+	}
+	if !parsed.Equal(ref) {
+		t.Errorf("dateParse(%q, %q) = %v, want %v", "short", got, parsed, ref)
+	}
+
+	// A custom alias registered on one Executor must not leak into another:
+	// cloneDateLayouts has to actually isolate each instance's map.
+	other := New()
+	if gotOther, _ := other.dateFormat("short", ref); gotOther != "short" {
+		t.Errorf("dateFormat(%q, ref) on a fresh Executor = %q, want the unresolved literal %q", "short", gotOther, "short")
+	}
+	if _, err := other.dateParse("short", got); err == nil {
+		t.Error("dateParse() resolved the \"short\" alias on a fresh Executor; RegisterDateLayout leaked across instances")
+	}
+}
+
+func TestDateIn(t *testing.T) {
+	ref := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	got, err := dateIn("America/New_York", ref)
+	if err != nil {
+		t.Fatalf("dateIn() error = %v", err)
+	}
+	if !got.Equal(ref) {
+		t.Errorf("dateIn() = %v, want equivalent instant to %v", got, ref)
+	}
+	if got.Location().String() != "America/New_York" {
+		t.Errorf("dateIn() location = %q, want %q", got.Location(), "America/New_York")
+	}
+}
@@ -0,0 +1,339 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// Executor is a reusable html/template engine built around this package's
+// Map of funcs. Unlike working with a bare *template.Template, it owns a
+// mutable, thread-safe FuncMap that can gain or lose entries after
+// templates have already been parsed, lazily re-parsing on its next use.
+type Executor struct {
+	mu sync.RWMutex
+
+	funcMap template.FuncMap
+	tmpl    *template.Template
+	sources []parseSource
+	dirty   bool
+
+	leftDelim  string
+	rightDelim string
+	missingKey string
+
+	clock       func() time.Time
+	location    *time.Location
+	dateLayouts map[string]string
+}
+
+// parseSource records a ParseFS or ParseGlob call so it can be replayed
+// against a freshly built base template after the FuncMap changes.
+type parseSource struct {
+	fsys     fs.FS
+	patterns []string
+}
+
+// Option configures an Executor constructed with New.
+type Option func(*Executor)
+
+// WithDelims sets both the left and right template action delimiters,
+// overriding the html/template defaults of "{{" and "}}".
+func WithDelims(left, right string) Option {
+	return func(e *Executor) {
+		e.leftDelim = left
+		e.rightDelim = right
+	}
+}
+
+// WithLeftDelim sets the left template action delimiter.
+func WithLeftDelim(left string) Option {
+	return func(e *Executor) {
+		e.leftDelim = left
+	}
+}
+
+// WithRightDelim sets the right template action delimiter.
+func WithRightDelim(right string) Option {
+	return func(e *Executor) {
+		e.rightDelim = right
+	}
+}
+
+// WithMissingKey controls how the Executor's templates behave when a map is
+// indexed with a key that isn't present. Valid values are "zero", "error",
+// and "default", matching text/template's Option("missingkey=...").
+// https://golang.org/pkg/text/template/#Template.Option
+func WithMissingKey(action string) Option {
+	return func(e *Executor) {
+		e.missingKey = action
+	}
+}
+
+// WithClock overrides the function the Executor's date funcs use in place
+// of time.Now, so that functions like dateFormat render deterministically
+// in tests.
+func WithClock(clock func() time.Time) Option {
+	return func(e *Executor) {
+		e.clock = clock
+	}
+}
+
+// WithLocation sets the *time.Location the Executor's dateFormat func renders
+// times in, instead of each time.Time's own location. The other date funcs
+// do not use it.
+func WithLocation(loc *time.Location) Option {
+	return func(e *Executor) {
+		e.location = loc
+	}
+}
+
+// New builds an Executor, preset with this package's Map of funcs, ready to
+// have templates parsed into it.
+func New(opts ...Option) *Executor {
+	e := &Executor{
+		funcMap:     make(template.FuncMap, len(Map)),
+		clock:       time.Now,
+		dateLayouts: cloneDateLayouts(),
+	}
+	for name, fn := range Map {
+		e.funcMap[name] = fn
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	// The date funcs are rebound per-executor so WithClock/WithLocation and
+	// RegisterDateLayout affect them.
+	e.funcMap["dateFormat"] = e.dateFormat
+	e.funcMap["dateParse"] = e.dateParse
+	e.funcMap["dateAgo"] = e.dateAgo
+	e.funcMap["dateIn"] = e.dateIn
+
+	e.tmpl = e.newBase()
+	return e
+}
+
+// RegisterDateLayout adds or overrides a named layout alias usable by this
+// Executor's dateFormat and dateParse funcs, e.g.
+// RegisterDateLayout("short", "1/2/2006").
+func (e *Executor) RegisterDateLayout(name, layout string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dateLayouts[strings.ToLower(name)] = layout
+}
+
+// resolveDateLayout resolves a named layout alias against this Executor's
+// dateLayouts, a copy of the package-level defaults plus any aliases added
+// with RegisterDateLayout. Anything it doesn't recognize is returned
+// unchanged.
+func (e *Executor) resolveDateLayout(layout string) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if l, ok := e.dateLayouts[strings.ToLower(layout)]; ok {
+		return l
+	}
+	return layout
+}
+
+// dateFormat is the per-executor replacement for the package-level
+// dateFormat func, using the Executor's clock in place of time.Now and, if
+// WithLocation was given, rendering in that fixed time zone.
+func (e *Executor) dateFormat(layout string, v interface{}) (string, error) {
+	var t time.Time
+	var err error
+
+	if v == nil {
+		t = e.clock()
+	} else {
+		t, err = cast.ToTimeE(v)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if e.location != nil {
+		t = t.In(e.location)
+	}
+
+	return formatDate(t, layout, e.resolveDateLayout), nil
+}
+
+// dateParse is the per-executor replacement for the package-level dateParse
+// func, resolving layout aliases registered on this Executor in addition to
+// the package defaults.
+func (e *Executor) dateParse(layout string, value interface{}) (time.Time, error) {
+	return parseDate(layout, value, e.resolveDateLayout)
+}
+
+// dateAgo is the per-executor replacement for the package-level dateAgo
+// func, measuring "now" from the Executor's clock instead of time.Now.
+func (e *Executor) dateAgo(v interface{}) (string, error) {
+	t, err := cast.ToTimeE(v)
+	if err != nil {
+		return "", err
+	}
+	return humanizeDateDiff(e.clock().Sub(t)), nil
+}
+
+// dateIn is the per-executor replacement for the package-level dateIn func.
+func (e *Executor) dateIn(tz string, v interface{}) (time.Time, error) {
+	return dateIn(tz, v)
+}
+
+// newBase builds a fresh, unparsed *template.Template carrying the
+// Executor's current delimiters, missing-key option, and FuncMap.
+func (e *Executor) newBase() *template.Template {
+	t := template.New("").Funcs(e.funcMap)
+	if e.leftDelim != "" || e.rightDelim != "" {
+		t = t.Delims(e.leftDelim, e.rightDelim)
+	}
+	if e.missingKey != "" {
+		t = t.Option("missingkey=" + e.missingKey)
+	}
+	return t
+}
+
+// Register adds or replaces a func in the Executor's FuncMap under name. It
+// takes effect the next time a template is parsed or executed; templates
+// already parsed before this call are re-parsed lazily from their original
+// sources on the next Execute/ExecuteContext call.
+func (e *Executor) Register(name string, fn interface{}) error {
+	if name == "" {
+		return fmt.Errorf("funcs: Register: name must not be empty")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.funcMap[name] = fn
+	e.dirty = true
+	return nil
+}
+
+// MustRegister is like Register but panics if Register returns an error.
+func (e *Executor) MustRegister(name string, fn interface{}) {
+	if err := e.Register(name, fn); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes a func from the Executor's FuncMap, taking effect the
+// same way Register's changes do.
+func (e *Executor) Unregister(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.funcMap, name)
+	e.dirty = true
+}
+
+// ParseFS parses the templates matching the given patterns out of fsys and
+// associates them with the Executor, in addition to any templates already
+// parsed.
+func (e *Executor) ParseFS(fsys fs.FS, patterns ...string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.dirty {
+		if err := e.reparseLocked(); err != nil {
+			return err
+		}
+	}
+
+	t, err := e.tmpl.ParseFS(fsys, patterns...)
+	if err != nil {
+		return err
+	}
+	e.tmpl = t
+	e.sources = append(e.sources, parseSource{fsys: fsys, patterns: patterns})
+	return nil
+}
+
+// ParseGlob parses the templates matching the given glob pattern off disk
+// and associates them with the Executor, in addition to any templates
+// already parsed.
+func (e *Executor) ParseGlob(pattern string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.dirty {
+		if err := e.reparseLocked(); err != nil {
+			return err
+		}
+	}
+
+	t, err := e.tmpl.ParseGlob(pattern)
+	if err != nil {
+		return err
+	}
+	e.tmpl = t
+	e.sources = append(e.sources, parseSource{patterns: []string{pattern}})
+	return nil
+}
+
+// reparseLocked rebuilds the Executor's template set from scratch against
+// its current FuncMap, replaying every ParseFS/ParseGlob call made so far.
+// Callers must hold e.mu for writing.
+func (e *Executor) reparseLocked() error {
+	t := e.newBase()
+	for _, src := range e.sources {
+		var err error
+		if src.fsys != nil {
+			t, err = t.ParseFS(src.fsys, src.patterns...)
+		} else {
+			t, err = t.ParseGlob(src.patterns[0])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	e.tmpl = t
+	e.dirty = false
+	return nil
+}
+
+// Execute renders the named template into w using data, re-parsing first if
+// the FuncMap has changed since the template set was last parsed.
+func (e *Executor) Execute(w io.Writer, name string, data interface{}) error {
+	e.mu.Lock()
+	if e.dirty {
+		if err := e.reparseLocked(); err != nil {
+			e.mu.Unlock()
+			return err
+		}
+	}
+	tmpl := e.tmpl
+	e.mu.Unlock()
+
+	if name == "" {
+		return tmpl.Execute(w, data)
+	}
+	return tmpl.ExecuteTemplate(w, name, data)
+}
+
+// ExecuteContext is like Execute, but once ctx is canceled the render is
+// aborted at its next write to w instead of running to completion.
+func (e *Executor) ExecuteContext(ctx context.Context, w io.Writer, name string, data interface{}) error {
+	return e.Execute(&contextWriter{ctx: ctx, w: w}, name, data)
+}
+
+// contextWriter wraps an io.Writer, rejecting writes once its context is
+// canceled so a long-running template render can be aborted mid-stream.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (c *contextWriter) Write(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.w.Write(p)
+}
@@ -0,0 +1,172 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var testTemplates = fstest.MapFS{
+	"greeting.tmpl": {Data: []byte("hello {{.}}")},
+	"shout.tmpl":    {Data: []byte("{{shout .}}")},
+}
+
+func TestExecutorExecute(t *testing.T) {
+	e := New()
+	if err := e.ParseFS(testTemplates, "greeting.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "greeting.tmpl", "world"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "hello world"; got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorRegisterReparses(t *testing.T) {
+	e := New()
+	e.MustRegister("shout", func(s string) string { return s })
+	if err := e.ParseFS(testTemplates, "shout.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	e.MustRegister("shout", func(s string) string { return s + "!" })
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "shout.tmpl", "hi"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "hi!"; got != want {
+		t.Errorf("Execute() after Register() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorWithClockAndLocation(t *testing.T) {
+	fixed := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	loc := time.FixedZone("UTC-5", -5*60*60)
+
+	e := New(
+		WithClock(func() time.Time { return fixed }),
+		WithLocation(loc),
+	)
+
+	got, err := e.dateFormat("rfc3339", nil)
+	if err != nil {
+		t.Fatalf("dateFormat() error = %v", err)
+	}
+	want := fixed.In(loc).Format(time.RFC3339)
+	if got != want {
+		t.Errorf("dateFormat() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorUnregister(t *testing.T) {
+	e := New()
+	e.MustRegister("shout", func(s string) string { return s + "!" })
+	if err := e.ParseFS(testTemplates, "shout.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "shout.tmpl", "hi"); err != nil {
+		t.Fatalf("Execute() before Unregister() error = %v", err)
+	}
+	if got, want := buf.String(), "hi!"; got != want {
+		t.Fatalf("Execute() before Unregister() = %q, want %q", got, want)
+	}
+
+	e.Unregister("shout")
+
+	if err := e.Execute(&bytes.Buffer{}, "shout.tmpl", "hi"); err == nil {
+		t.Error("Execute() after Unregister() error = nil, want error for the now-undefined shout func")
+	}
+}
+
+func TestExecutorWithDelims(t *testing.T) {
+	e := New(WithDelims("[[", "]]"))
+	if err := e.ParseFS(fstest.MapFS{"brackets.tmpl": {Data: []byte("[[.]]")}}, "brackets.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "brackets.tmpl", "world"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "world"; got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorWithLeftAndRightDelim(t *testing.T) {
+	e := New(WithLeftDelim("<%"), WithRightDelim("%>"))
+	if err := e.ParseFS(fstest.MapFS{"percents.tmpl": {Data: []byte("<%.%>")}}, "percents.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "percents.tmpl", "world"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "world"; got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorWithMissingKey(t *testing.T) {
+	e := New(WithMissingKey("zero"))
+	if err := e.ParseFS(fstest.MapFS{"missing.tmpl": {Data: []byte("[{{.Missing}}]")}}, "missing.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "missing.tmpl", map[string]interface{}{}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "[]"; got != want {
+		t.Errorf("Execute() with missingkey=zero = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorParseGlob(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hello.tmpl")
+	if err := os.WriteFile(path, []byte("hi {{.}}"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	e := New()
+	if err := e.ParseGlob(filepath.Join(dir, "*.tmpl")); err != nil {
+		t.Fatalf("ParseGlob() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.Execute(&buf, "hello.tmpl", "there"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got, want := buf.String(), "hi there"; got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
+
+func TestExecutorExecuteContext(t *testing.T) {
+	e := New()
+	if err := e.ParseFS(testTemplates, "greeting.tmpl"); err != nil {
+		t.Fatalf("ParseFS() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := e.ExecuteContext(ctx, &bytes.Buffer{}, "greeting.tmpl", "world")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ExecuteContext() error = %v, want context.Canceled", err)
+	}
+}
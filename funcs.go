@@ -15,10 +15,21 @@ import (
 // Map is a map of useful template funcs available for FuncMap use.
 var Map = template.FuncMap{
 	"dateFormat":   dateFormat,
+	"dateParse":    dateParse,
+	"dateAgo":      dateAgo,
+	"dateIn":       dateIn,
 	"htmlEscape":   htmlEscape,
 	"htmlUnescape": htmlUnescape,
 	"safeHTML":     safeHTML,
 	"safeURL":      safeURL,
+	"safeCSS":      safeCSS,
+	"safeJS":       safeJS,
+	"safeJSStr":    safeJSStr,
+	"safeHTMLAttr": safeHTMLAttr,
+	"noescape":     noescape,
+	"stripTags":    stripTags,
+	"urlComponent": urlComponent,
+	"urlFilter":    urlFilter,
 	"dict":         dictionary,
 	"querify":      querify,
 	"split":        split,
@@ -34,6 +45,10 @@ func loop(n int) []struct{} {
 // dateFormat formats a textual representation of a datetime string into the
 // specified layout. If nil is provided as the textual datetime it will be
 // replaced with time.Now.
+//
+// layout may be a Go time-layout string, one of the named aliases
+// registered in defaultDateLayouts (e.g. "rfc3339", "kitchen"), or one of
+// the "unix" / "unixmilli" epoch pseudo-layouts.
 // https://golang.org/pkg/time/#pkg-constants
 func dateFormat(layout string, v interface{}) (string, error) {
 	var t time.Time
@@ -48,16 +63,66 @@ func dateFormat(layout string, v interface{}) (string, error) {
 		}
 	}
 
-	return t.Format(layout), nil
+	return formatDate(t, layout, resolveDateLayout), nil
+}
+
+// contentType identifies a value that has already been wrapped in one of
+// html/template's known-safe content types by one of this package's safe*
+// helpers, so that downstream funcs can decide whether it's safe to skip
+// re-escaping it.
+type contentType int
+
+// The zero value, contentTypePlain, means the value is an ordinary string
+// that has not been vetted as safe for any particular context.
+const (
+	contentTypePlain contentType = iota
+	contentTypeHTML
+	contentTypeCSS
+	contentTypeJS
+	contentTypeJSStr
+	contentTypeHTMLAttr
+	contentTypeURL
+)
+
+// stringify unwraps a single html/template safe-content type (template.HTML,
+// template.CSS, template.JS, template.JSStr, template.HTMLAttr or
+// template.URL) into its underlying string and reports which content type it
+// was. Any other input, including multiple arguments, is stringified with
+// fmt.Sprint and reported as contentTypePlain.
+func stringify(args ...interface{}) (string, contentType) {
+	if len(args) == 1 {
+		switch v := args[0].(type) {
+		case template.HTML:
+			return string(v), contentTypeHTML
+		case template.CSS:
+			return string(v), contentTypeCSS
+		case template.JS:
+			return string(v), contentTypeJS
+		case template.JSStr:
+			return string(v), contentTypeJSStr
+		case template.HTMLAttr:
+			return string(v), contentTypeHTMLAttr
+		case template.URL:
+			return string(v), contentTypeURL
+		}
+	}
+	return fmt.Sprint(args...), contentTypePlain
 }
 
 // htmlEscape returns the given string with critical reserved HTML codes
 // escaped, such that `&` becomes `&amp;` and so on. Only the `<`, `>`, `&`,
 // `_`, `'`, and `"` characters are escaped.
 //
+// If the input is already known-safe HTML, e.g. the result of `safeHTML` or
+// `noescape`, it's returned unchanged instead of being escaped again.
+//
 // Keep in mind that, unless content is passed through `safeHTML`, output
 // strings are escaped in default settings by the processor anyway.
 func htmlEscape(in interface{}) (string, error) {
+	if s, ctype := stringify(in); ctype == contentTypeHTML {
+		return s, nil
+	}
+
 	conv, err := cast.ToStringE(in)
 	if err != nil {
 		return "", err
@@ -130,6 +195,50 @@ func safeURL(a interface{}) (template.URL, error) {
 	return template.URL(s), err
 }
 
+// safeCSS returns a given string as a html/template known-safe CSS fragment,
+// instructing template parsers to output its content verbatim.
+// https://golang.org/pkg/html/template/#CSS
+func safeCSS(a interface{}) (template.CSS, error) {
+	s, err := cast.ToStringE(a)
+	return template.CSS(s), err
+}
+
+// safeJS returns a given string as a html/template known-safe JavaScript
+// expression, instructing template parsers to output its content verbatim.
+// https://golang.org/pkg/html/template/#JS
+func safeJS(a interface{}) (template.JS, error) {
+	s, err := cast.ToStringE(a)
+	return template.JS(s), err
+}
+
+// safeJSStr returns a given string as a html/template known-safe JavaScript
+// string literal body, instructing template parsers to output its content
+// verbatim. Unlike safeJS, this is for use inside an already-quoted string,
+// e.g. `var s = "{{ .Value | safeJSStr }}";`.
+// https://golang.org/pkg/html/template/#JSStr
+func safeJSStr(a interface{}) (template.JSStr, error) {
+	s, err := cast.ToStringE(a)
+	return template.JSStr(s), err
+}
+
+// safeHTMLAttr returns a given string as a html/template known-safe HTML
+// attribute, instructing template parsers to output its content verbatim.
+// https://golang.org/pkg/html/template/#HTMLAttr
+func safeHTMLAttr(a interface{}) (template.HTMLAttr, error) {
+	s, err := cast.ToStringE(a)
+	return template.HTMLAttr(s), err
+}
+
+// noescape marks the final value of a pipeline as known-safe HTML, letting a
+// trusted value opt out of html/template's default contextual auto-escaping,
+// e.g. `{{ .Trusted | noescape }}`. The value is stringified first, so a
+// value already wrapped by one of this package's safe* helpers keeps its
+// original content rather than being stringified twice.
+func noescape(a interface{}) template.HTML {
+	s, _ := stringify(a)
+	return template.HTML(s)
+}
+
 // split slices s string into all substrings separated by sep and returns a
 // slice of the substrings between those separators.
 // https://golang.org/pkg/strings/#Split
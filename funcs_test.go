@@ -0,0 +1,60 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestSafeWrappers(t *testing.T) {
+	if v, err := safeCSS("a { color: red }"); err != nil || v != template.CSS("a { color: red }") {
+		t.Errorf("safeCSS() = %q, %v", v, err)
+	}
+	if v, err := safeJS("alert(1)"); err != nil || v != template.JS("alert(1)") {
+		t.Errorf("safeJS() = %q, %v", v, err)
+	}
+	if v, err := safeJSStr(`a"b`); err != nil || v != template.JSStr(`a"b`) {
+		t.Errorf("safeJSStr() = %q, %v", v, err)
+	}
+	if v, err := safeHTMLAttr(`disabled`); err != nil || v != template.HTMLAttr(`disabled`) {
+		t.Errorf("safeHTMLAttr() = %q, %v", v, err)
+	}
+}
+
+func TestNoescape(t *testing.T) {
+	if got := noescape("<b>hi</b>"); got != template.HTML("<b>hi</b>") {
+		t.Errorf("noescape(string) = %q, want %q", got, "<b>hi</b>")
+	}
+	if got := noescape(template.CSS("a:b")); got != template.HTML("a:b") {
+		t.Errorf("noescape(template.CSS) = %q, want %q", got, "a:b")
+	}
+}
+
+func TestHtmlEscapeNoopOnSafeHTML(t *testing.T) {
+	in := template.HTML(`<b>&amp;</b>`)
+	got, err := htmlEscape(in)
+	if err != nil {
+		t.Fatalf("htmlEscape() error = %v", err)
+	}
+	if got != string(in) {
+		t.Errorf("htmlEscape(template.HTML) = %q, want unchanged %q", got, in)
+	}
+}
+
+func TestHtmlEscapePlainString(t *testing.T) {
+	got, err := htmlEscape(`<b>`)
+	if err != nil {
+		t.Fatalf("htmlEscape() error = %v", err)
+	}
+	if got != "&lt;b&gt;" {
+		t.Errorf("htmlEscape(`<b>`) = %q, want %q", got, "&lt;b&gt;")
+	}
+}
+
+func TestStringify(t *testing.T) {
+	if s, ctype := stringify(template.JS("x")); s != "x" || ctype != contentTypeJS {
+		t.Errorf("stringify(template.JS) = %q, %v, want %q, %v", s, ctype, "x", contentTypeJS)
+	}
+	if s, ctype := stringify("a", "b"); s != "ab" || ctype != contentTypePlain {
+		t.Errorf("stringify(multi) = %q, %v, want %q, %v", s, ctype, "ab", contentTypePlain)
+	}
+}
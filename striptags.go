@@ -0,0 +1,174 @@
+package funcs
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cast"
+)
+
+// rawTextElements are elements whose entire body, including any markup it
+// contains, must be dropped rather than merely having its tags stripped.
+// https://html.spec.whatwg.org/multipage/syntax.html#raw-text-elements
+var rawTextElements = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// stripTagsState is the state of the stripTags rune-by-rune scanner.
+type stripTagsState int
+
+const (
+	stripTagsOutsideTag stripTagsState = iota
+	stripTagsTagName
+	stripTagsAttr
+	stripTagsQuotedAttr
+	stripTagsComment
+	stripTagsCDATA
+	stripTagsRawText
+)
+
+// stripTags removes all HTML markup from the given value and decodes any
+// HTML entities in what remains, the same reduction html/template performs
+// internally when rendering HTML into an unquoted attribute context. It
+// drops comments (`<!-- ... -->`) and CDATA sections (`<![CDATA[ ... ]]>`)
+// entirely, and drops the bodies of RAWTEXT elements such as `<script>` and
+// `<style>` up to their matching close tag. Runs of ASCII whitespace in the
+// remaining text are collapsed to a single space.
+func stripTags(in interface{}) (string, error) {
+	s, err := cast.ToStringE(in)
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	runes := []rune(s)
+	state := stripTagsOutsideTag
+	var quote rune
+	var tagName strings.Builder
+	var rawTextTag string
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch state {
+		case stripTagsOutsideTag:
+			if r == '<' {
+				if strings.HasPrefix(string(runes[i:]), "<!--") {
+					state = stripTagsComment
+					i += 3
+					continue
+				}
+				if strings.HasPrefix(string(runes[i:]), "<![CDATA[") {
+					state = stripTagsCDATA
+					i += 8
+					continue
+				}
+				tagName.Reset()
+				state = stripTagsTagName
+				continue
+			}
+			out.WriteRune(r)
+
+		case stripTagsTagName:
+			switch {
+			case r == '>':
+				if name := strings.ToLower(strings.TrimPrefix(tagName.String(), "/")); rawTextElements[name] && !strings.HasPrefix(tagName.String(), "/") {
+					rawTextTag = name
+					state = stripTagsRawText
+				} else {
+					state = stripTagsOutsideTag
+				}
+			case r == '"' || r == '\'':
+				quote = r
+				state = stripTagsQuotedAttr
+			case unicode.IsSpace(r):
+				state = stripTagsAttr
+			default:
+				tagName.WriteRune(r)
+			}
+
+		case stripTagsAttr:
+			switch {
+			case r == '>':
+				if name := strings.ToLower(strings.TrimPrefix(tagName.String(), "/")); rawTextElements[name] && !strings.HasPrefix(tagName.String(), "/") {
+					rawTextTag = name
+					state = stripTagsRawText
+				} else {
+					state = stripTagsOutsideTag
+				}
+			case r == '"' || r == '\'':
+				quote = r
+				state = stripTagsQuotedAttr
+			}
+
+		case stripTagsQuotedAttr:
+			if r == quote {
+				state = stripTagsAttr
+			}
+
+		case stripTagsComment:
+			if strings.HasPrefix(string(runes[i:]), "-->") {
+				i += 2
+				state = stripTagsOutsideTag
+			}
+
+		case stripTagsCDATA:
+			if strings.HasPrefix(string(runes[i:]), "]]>") {
+				i += 2
+				state = stripTagsOutsideTag
+			}
+
+		case stripTagsRawText:
+			if r == '<' {
+				rest := strings.ToLower(string(runes[i:]))
+				prefix := "</" + rawTextTag
+				if strings.HasPrefix(rest, prefix) && rawTextCloseBoundary(rest, len(prefix)) {
+					tagName.Reset()
+					state = stripTagsTagName
+					i += len(rawTextTag) + 1
+				}
+			}
+		}
+	}
+
+	return collapseWhitespace(html.UnescapeString(out.String())), nil
+}
+
+// rawTextCloseBoundary reports whether s has a tag-name boundary (end of
+// string, `>`, `/`, or whitespace) at byte offset i, so that a RAWTEXT close
+// tag like "</script>" isn't matched against a longer identifier that merely
+// starts with the same prefix, e.g. the string literal "</scriptTwo>"
+// appearing inside a <script> element's body.
+func rawTextCloseBoundary(s string, i int) bool {
+	if i >= len(s) {
+		return true
+	}
+	switch c := s[i]; {
+	case c == '>' || c == '/':
+		return true
+	case unicode.IsSpace(rune(c)):
+		return true
+	}
+	return false
+}
+
+// collapseWhitespace replaces every run of ASCII whitespace with a single
+// space.
+func collapseWhitespace(s string) string {
+	var b strings.Builder
+	lastWasSpace := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' || r == '\v' {
+			if !lastWasSpace {
+				b.WriteByte(' ')
+			}
+			lastWasSpace = true
+			continue
+		}
+		lastWasSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package funcs
+
+import "testing"
+
+func TestStripTags(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "plain text",
+			in:   "hello world",
+			want: "hello world",
+		},
+		{
+			name: "simple tags",
+			in:   "<p>hello <b>world</b></p>",
+			want: "hello world",
+		},
+		{
+			name: "quoted attr containing a gt",
+			in:   `<img alt="a>b" src="x.png">after`,
+			want: "after",
+		},
+		{
+			name: "entities are decoded",
+			in:   "a &amp; b &lt;c&gt;",
+			want: "a & b <c>",
+		},
+		{
+			name: "whitespace collapses",
+			in:   "a   b\n\tc",
+			want: "a b c",
+		},
+		{
+			name: "comment is dropped",
+			in:   "a<!-- comment <b> -->b",
+			want: "ab",
+		},
+		{
+			name: "CDATA is dropped",
+			in:   "a<![CDATA[<b>not a tag]]>b",
+			want: "ab",
+		},
+		{
+			name: "script body is dropped entirely",
+			in:   `<script>var x = "<p>not html</p>";</script>after`,
+			want: "after",
+		},
+		{
+			name: "script body containing the tag name as a substring doesn't end it early",
+			in:   `<script>var x = "</scriptTwo>"; alert(1); </script>after`,
+			want: "after",
+		},
+		{
+			name: "style body is dropped entirely",
+			in:   `<style>p { content: "</style"; }</style>after`,
+			want: "after",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := stripTags(tc.in)
+			if err != nil {
+				t.Fatalf("stripTags(%q) error = %v", tc.in, err)
+			}
+			if got != tc.want {
+				t.Errorf("stripTags(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package funcs
+
+import (
+	"html/template"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cast"
+)
+
+// urlUnreservedChar reports whether b is one of the RFC 3986 unreserved
+// characters that encodeURIComponent leaves untouched.
+func urlUnreservedChar(b byte) bool {
+	switch {
+	case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9':
+		return true
+	case b == '-' || b == '_' || b == '.' || b == '~':
+		return true
+	}
+	return false
+}
+
+// urlComponent percent-encodes a string the same way JavaScript's
+// encodeURIComponent does: every byte is escaped except the RFC 3986
+// unreserved characters `A-Z a-z 0-9 - _ . ~`. Unlike url.QueryEscape, a
+// space is encoded as `%20` rather than `+`, and `!*'()` are percent-encoded
+// rather than left bare.
+func urlComponent(a interface{}) (string, error) {
+	s, err := cast.ToStringE(a)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	const upperhex = "0123456789ABCDEF"
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if urlUnreservedChar(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&0xf])
+	}
+
+	return b.String(), nil
+}
+
+// urlFilterAllowedSchemes are the URL schemes urlFilter lets through
+// untouched, alongside relative URLs and `#fragment` references.
+var urlFilterAllowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"tel":    true,
+	"ftp":    true,
+}
+
+// urlFilterZgotmplZ is the sentinel html/template substitutes for a URL with
+// a disallowed scheme, so that a broken link is visibly inert rather than
+// silently executing attacker-controlled script.
+// https://golang.org/pkg/html/template/#hdr-Security_Model
+const urlFilterZgotmplZ = "#ZgotmplZ"
+
+// urlFilter inspects a URL's scheme and, unless it's on an allow-list
+// (http, https, mailto, tel, ftp, a relative URL, or a `#...` fragment),
+// returns the sentinel "#ZgotmplZ" instead of the URL, mirroring
+// html/template's defanging of unsafe schemes. A `data:` URL is only
+// permitted when it's an image (`data:image/png|gif|jpeg|webp`). Scheme
+// detection is case-insensitive and tolerates leading whitespace and
+// control characters.
+func urlFilter(a interface{}) (template.URL, error) {
+	s, err := cast.ToStringE(a)
+	if err != nil {
+		return "", err
+	}
+
+	trimmed := strings.TrimLeftFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	})
+
+	if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '/' {
+		return template.URL(s), nil
+	}
+
+	scheme, rest, found := strings.Cut(trimmed, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return template.URL(s), nil
+	}
+	scheme = strings.ToLower(scheme)
+
+	if scheme == "data" {
+		rest = strings.ToLower(rest)
+		for _, prefix := range []string{"image/png", "image/gif", "image/jpeg", "image/webp"} {
+			if strings.HasPrefix(rest, prefix) {
+				return template.URL(s), nil
+			}
+		}
+		return template.URL(urlFilterZgotmplZ), nil
+	}
+
+	if urlFilterAllowedSchemes[scheme] {
+		return template.URL(s), nil
+	}
+
+	return template.URL(urlFilterZgotmplZ), nil
+}
@@ -0,0 +1,59 @@
+package funcs
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestUrlComponent(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"abc123-_.~", "abc123-_.~"},
+		{"a b", "a%20b"},
+		{"!*'()", "%21%2A%27%28%29"},
+		{"a/b?c=d&e", "a%2Fb%3Fc%3Dd%26e"},
+	}
+
+	for _, tc := range cases {
+		got, err := urlComponent(tc.in)
+		if err != nil {
+			t.Fatalf("urlComponent(%q) error = %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("urlComponent(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestUrlFilter(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want template.URL
+	}{
+		{"http allowed", "http://example.com/a", "http://example.com/a"},
+		{"https allowed", "https://example.com/a", "https://example.com/a"},
+		{"mailto allowed", "mailto:a@example.com", "mailto:a@example.com"},
+		{"relative path allowed", "/a/b", "/a/b"},
+		{"fragment allowed", "#section", "#section"},
+		{"javascript scheme defanged", "javascript:alert(1)", urlFilterZgotmplZ},
+		{"data image allowed", "data:image/png;base64,AAAA", "data:image/png;base64,AAAA"},
+		{"data non-image defanged", "data:text/html,<script>", urlFilterZgotmplZ},
+		{"relative path with a colon is not a scheme", "images/photo:2024.jpg", "images/photo:2024.jpg"},
+		{"relative path segment with a colon is not a scheme", "foo/bar:baz", "foo/bar:baz"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := urlFilter(tc.in)
+			if err != nil {
+				t.Fatalf("urlFilter(%q) error = %v", tc.in, err)
+			}
+			if got != tc.want {
+				t.Errorf("urlFilter(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}